@@ -0,0 +1,136 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrorCode is a stable, numeric identifier for a class of API error, so
+// clients can switch on it instead of parsing Message.
+type ErrorCode int
+
+const (
+	CodeTaskNotFound        ErrorCode = 100
+	CodeTaskAlreadyExists   ErrorCode = 101
+	CodeClientClosedRequest ErrorCode = 102
+	CodeTimeout             ErrorCode = 103
+
+	CodeValidationFailed ErrorCode = 200
+
+	CodeUserAlreadyExists  ErrorCode = 300
+	CodeInvalidCredentials ErrorCode = 301
+	CodeUnauthenticated    ErrorCode = 302
+	CodeForbidden          ErrorCode = 303
+	CodeUserNotFound       ErrorCode = 304
+
+	CodeRunNotFound          ErrorCode = 400
+	CodeRunNotRunnable       ErrorCode = 401
+	CodeStreamingUnsupported ErrorCode = 402
+
+	CodeInternal ErrorCode = 500
+)
+
+// codeStatus maps each ErrorCode to the HTTP status it should produce.
+var codeStatus = map[ErrorCode]int{
+	CodeTaskNotFound:        http.StatusNotFound,
+	CodeTaskAlreadyExists:   http.StatusConflict,
+	CodeClientClosedRequest: statusClientClosedRequest,
+	CodeTimeout:             http.StatusGatewayTimeout,
+
+	CodeValidationFailed: http.StatusBadRequest,
+
+	CodeUserAlreadyExists:  http.StatusConflict,
+	CodeInvalidCredentials: http.StatusUnauthorized,
+	CodeUnauthenticated:    http.StatusUnauthorized,
+	CodeForbidden:          http.StatusForbidden,
+	CodeUserNotFound:       http.StatusNotFound,
+
+	CodeRunNotFound:          http.StatusNotFound,
+	CodeRunNotRunnable:       http.StatusBadRequest,
+	CodeStreamingUnsupported: http.StatusInternalServerError,
+
+	CodeInternal: http.StatusInternalServerError,
+}
+
+// statusClientClosedRequest is nginx's de facto convention for a request
+// whose client disconnected before the response was ready.
+const statusClientClosedRequest = 499
+
+// APIError is a classified API error, modeled on etcd's client Error type:
+// a stable numeric code clients can switch on, a human message, an
+// optional wrapped cause, and a timestamp.
+type APIError struct {
+	ErrorCode ErrorCode `json:"code"`
+	Message   string    `json:"message"`
+	Cause     string    `json:"cause,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewAPIError builds an APIError, stamping the current time.
+func NewAPIError(code ErrorCode, message string) *APIError {
+	return &APIError{
+		ErrorCode: code,
+		Message:   message,
+		Timestamp: time.Now(),
+	}
+}
+
+// WithCause attaches the underlying error that produced e, so clients (or
+// logs) can see the original detail behind a generic message.
+func (e *APIError) WithCause(cause error) *APIError {
+	if cause != nil {
+		e.Cause = cause.Error()
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Status maps e's code to an HTTP status, falling back to 500 for a code
+// missing from the catalogue.
+func (e *APIError) Status() int {
+	if status, ok := codeStatus[e.ErrorCode]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// writeError unwraps err into an *APIError (falling back to a generic 500
+// for anything else) and writes it as the JSON response body.
+func writeError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		apiErr = NewAPIError(CodeInternal, "internal server error").WithCause(err)
+	}
+
+	b, marshalErr := json.MarshalIndent(apiErr, "", "  ")
+	if marshalErr != nil {
+		panic(marshalErr)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.Status())
+	_, _ = w.Write(b)
+}
+
+// RecoverMiddleware turns a panicking handler into a 500 APIError response
+// instead of crashing the server.
+func RecoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				err, ok := rec.(error)
+				if !ok {
+					err = fmt.Errorf("%v", rec)
+				}
+				writeError(w, NewAPIError(CodeInternal, "internal server error").WithCause(err))
+			}
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
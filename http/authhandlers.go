@@ -0,0 +1,119 @@
+package http
+
+import (
+	"RestAPI/users"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type AuthHandlers struct {
+	registry *users.Registry
+}
+
+func NewAuthHandlers(registry *users.Registry) *AuthHandlers {
+	return &AuthHandlers{
+		registry: registry,
+	}
+}
+
+type CredentialsDTO struct {
+	Username string
+	Password string
+}
+
+func (d CredentialsDTO) Validate() error {
+	if d.Username == "" {
+		return errors.New("username is required")
+	}
+	if d.Password == "" {
+		return errors.New("password is required")
+	}
+
+	return nil
+}
+
+type TokenDTO struct {
+	Token string
+}
+
+/*
+pattern: /signup
+method: POST
+info: JSON in HTTP request body
+
+succeed:
+	-status code: 201 Created
+
+failed:
+	-status code: 400, 409, 500
+	-response body: JSON APIError
+*/
+
+func (h *AuthHandlers) HandleSignup(w http.ResponseWriter, r *http.Request) {
+	var credsDTO CredentialsDTO
+	if err := json.NewDecoder(r.Body).Decode(&credsDTO); err != nil {
+		writeError(w, NewAPIError(CodeValidationFailed, err.Error()))
+		return
+	}
+
+	if err := credsDTO.Validate(); err != nil {
+		writeError(w, NewAPIError(CodeValidationFailed, err.Error()))
+		return
+	}
+
+	if _, err := h.registry.Signup(credsDTO.Username, credsDTO.Password); err != nil {
+		if errors.Is(err, users.ErrUserAlreadyExists) {
+			writeError(w, NewAPIError(CodeUserAlreadyExists, err.Error()))
+		} else {
+			writeError(w, NewAPIError(CodeInternal, "internal server error").WithCause(err))
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+/*
+pattern: /login
+method: POST
+info: JSON in HTTP request body
+
+succeed:
+	-status code: 200 OK
+	-response body: JSON with a bearer token
+
+failed:
+	-status code: 400, 401, 500
+	-response body: JSON APIError
+*/
+
+func (h *AuthHandlers) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	var credsDTO CredentialsDTO
+	if err := json.NewDecoder(r.Body).Decode(&credsDTO); err != nil {
+		writeError(w, NewAPIError(CodeValidationFailed, err.Error()))
+		return
+	}
+
+	token, err := h.registry.Login(credsDTO.Username, credsDTO.Password)
+	if err != nil {
+		if errors.Is(err, users.ErrInvalidCredentials) {
+			writeError(w, NewAPIError(CodeInvalidCredentials, err.Error()))
+		} else {
+			writeError(w, NewAPIError(CodeInternal, "internal server error").WithCause(err))
+		}
+		return
+	}
+
+	b, err := json.MarshalIndent(TokenDTO{Token: token}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(b); err != nil {
+		fmt.Println("failed to write http response", err)
+		return
+	}
+}
@@ -0,0 +1,23 @@
+package http
+
+import (
+	"RestAPI/users"
+	"context"
+)
+
+type ctxKey string
+
+const (
+	userIDKey ctxKey = "userID"
+	roleKey   ctxKey = "role"
+)
+
+func userIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userIDKey).(string)
+	return id, ok
+}
+
+func roleFromContext(ctx context.Context) (users.Role, bool) {
+	role, ok := ctx.Value(roleKey).(users.Role)
+	return role, ok
+}
@@ -1,10 +1,6 @@
 package http
 
-import (
-	"encoding/json"
-	"errors"
-	"time"
-)
+import "errors"
 
 //DTO == data transfer object
 
@@ -15,6 +11,10 @@ type CompleteTaskDTO struct {
 type TaskDTO struct {
 	Title       string
 	Description string
+
+	Command     string
+	Args        []string
+	MaxAttempts int
 }
 
 func (t TaskDTO) ValidateForCreate() error {
@@ -27,18 +27,3 @@ func (t TaskDTO) ValidateForCreate() error {
 
 	return nil
 }
-
-type ErrorDTO struct {
-	Message string
-	Time    time.Time
-}
-
-func (e ErrorDTO) ToString() string {
-
-	b, err := json.MarshalIndent(e, "", "	")
-	if err != nil {
-		panic(err)
-	}
-
-	return string(b)
-}
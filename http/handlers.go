@@ -1,26 +1,67 @@
 package http
 
 import (
+	"RestAPI/runner"
 	"RestAPI/todo"
+	"RestAPI/users"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/gorilla/mux"
 )
 
+// listAPIError classifies an error returned from a todo.List call,
+// including its context-aware variants, into an *APIError.
+func listAPIError(err error) *APIError {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return NewAPIError(CodeClientClosedRequest, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewAPIError(CodeTimeout, err.Error())
+	case errors.Is(err, todo.ErrTaskNotFound):
+		return NewAPIError(CodeTaskNotFound, err.Error())
+	case errors.Is(err, todo.ErrTaskAlreadyExists):
+		return NewAPIError(CodeTaskAlreadyExists, err.Error())
+	default:
+		return NewAPIError(CodeInternal, "internal server error").WithCause(err)
+	}
+}
+
 type HTTPHandlers struct {
-	todoList *todo.List
+	lists    *todo.Lists
+	runner   *runner.Pool
+	registry *users.Registry
 }
 
-func NewHTTPHandlers(todoList *todo.List) *HTTPHandlers {
+func NewHTTPHandlers(lists *todo.Lists, pool *runner.Pool, registry *users.Registry) *HTTPHandlers {
 	return &HTTPHandlers{
-		todoList: todoList,
+		lists:    lists,
+		runner:   pool,
+		registry: registry,
 	}
 }
 
+// callerList resolves the todo.List belonging to the authenticated caller,
+// as attached to the request context by AuthMiddleware.
+func (h *HTTPHandlers) callerList(w http.ResponseWriter, r *http.Request) (*todo.List, bool) {
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		writeError(w, NewAPIError(CodeUnauthenticated, "missing authenticated user"))
+		return nil, false
+	}
+
+	list, err := h.lists.ListFor(userID)
+	if err != nil {
+		writeError(w, NewAPIError(CodeInternal, "internal server error").WithCause(err))
+		return nil, false
+	}
+
+	return list, true
+}
+
 /*
 pattern: /tasks
 method: POST
@@ -31,44 +72,37 @@ succeed:
 	-response body: JSON represent created task
 
 failed:
-	status code: 400, 409, 500, ....
-	response body: JSON with error + time
+	status code: 400, 409, 500
+	response body: JSON APIError
 */
 
 func (h *HTTPHandlers) HandleCreateTasks(w http.ResponseWriter, r *http.Request) {
 	var taskDTO TaskDTO
 	if err := json.NewDecoder(r.Body).Decode(&taskDTO); err != nil {
-		errDTO := ErrorDTO{
-			Message: err.Error(),
-			Time:    time.Now(),
-		}
-		http.Error(w, errDTO.ToString(), http.StatusBadRequest)
+		writeError(w, NewAPIError(CodeValidationFailed, err.Error()))
 		return
 	}
 
 	if err := taskDTO.ValidateForCreate(); err != nil {
-		errDTO := ErrorDTO{
-			Message: err.Error(),
-			Time:    time.Now(),
-		}
-		http.Error(w, errDTO.ToString(), http.StatusBadRequest)
+		writeError(w, NewAPIError(CodeValidationFailed, err.Error()))
+		return
+	}
+
+	list, ok := h.callerList(w, r)
+	if !ok {
 		return
 	}
 
 	todoTask := todo.NewTask(taskDTO.Title, taskDTO.Description)
-	if err := h.todoList.AddTask(todoTask); err != nil {
-		errDTO := ErrorDTO{
-			Message: err.Error(),
-			Time:    time.Now(),
-		}
-		if errors.Is(err, todo.ErrTaskAlreadyExists) {
-			http.Error(w, errDTO.ToString(), http.StatusConflict)
-		} else {
-			http.Error(w, errDTO.ToString(), http.StatusInternalServerError)
-		}
+	todoTask.Command = taskDTO.Command
+	todoTask.Args = taskDTO.Args
+	todoTask.MaxAttempts = taskDTO.MaxAttempts
 
+	if err := list.AddTaskCtx(r.Context(), todoTask); err != nil {
+		writeError(w, listAPIError(err))
 		return
 	}
+
 	b, err := json.MarshalIndent(todoTask, "", "  ")
 	if err != nil {
 		panic(err)
@@ -91,26 +125,24 @@ succeed:
 	-response body: JSON represented found task
 
 failed:
-	-status code: 400, 404, 500
-	-response body: JSON with error + time
+	-status code: 404, 500
+	-response body: JSON APIError
 */
 
 func (h *HTTPHandlers) HandleGetTask(w http.ResponseWriter, r *http.Request) {
 	title := mux.Vars(r)["title"]
 
-	task, err := h.todoList.GetTask(title)
+	list, ok := h.callerList(w, r)
+	if !ok {
+		return
+	}
+
+	task, err := list.GetTaskCtx(r.Context(), title)
 	if err != nil {
-		errDTO := ErrorDTO{
-			Message: err.Error(),
-			Time:    time.Now(),
-		}
-		if errors.Is(err, todo.ErrTaskNotFound) {
-			http.Error(w, errDTO.ToString(), http.StatusNotFound)
-		} else {
-			http.Error(w, errDTO.ToString(), http.StatusInternalServerError)
-		}
+		writeError(w, listAPIError(err))
 		return
 	}
+
 	b, err := json.MarshalIndent(task, "", "  ")
 	if err != nil {
 		panic(err)
@@ -123,49 +155,43 @@ func (h *HTTPHandlers) HandleGetTask(w http.ResponseWriter, r *http.Request) {
 }
 
 /*
-pattern: /tasks/{title}
+pattern: /tasks?completed=&created_after=&created_before=&sort=&order=&limit=&cursor=
 method: GET
-info: pattern
+info: query params, all optional
 
 succeed:
 	-status code: 200 OK
-	-response body: JSON represented found tasks
+	-response body: JSON {items: [...], next_cursor: "..."}
 
 failed:
 	-status code: 400, 500
-	-response body: JSON with error + time
+	-response body: JSON APIError
 */
 
 func (h *HTTPHandlers) HandleGetALLTasks(w http.ResponseWriter, r *http.Request) {
-	tasks := h.todoList.ListTasks()
-	b, err := json.MarshalIndent(tasks, "", "  ")
-	if err != nil {
-		panic(err)
-	}
-	w.WriteHeader(http.StatusOK)
-	if _, err := w.Write(b); err != nil {
-		fmt.Println("failed to write http response", err)
+	list, ok := h.callerList(w, r)
+	if !ok {
 		return
 	}
-}
 
-/*
-pattern: /tasks?completed=true
-method: GET
-info: query params
+	opts, err := parseQueryOptions(r.URL.Query())
+	if err != nil {
+		writeError(w, NewAPIError(CodeValidationFailed, err.Error()))
+		return
+	}
 
-succeed:
-	-status code: 200 OK
-	-response body: JSON represented found tasks
+	page, err := list.Query(opts)
+	if err != nil {
+		writeError(w, NewAPIError(CodeValidationFailed, err.Error()))
+		return
+	}
 
-failed:
-	-status code: 400, 500
-	-response body: JSON with error + time
-*/
+	pageDTO := TasksPageDTO{Items: page.Items, NextCursor: page.NextCursor}
+	if pageDTO.Items == nil {
+		pageDTO.Items = []todo.Task{}
+	}
 
-func (h *HTTPHandlers) HandleGetAllUncompletedTasks(w http.ResponseWriter, r *http.Request) {
-	uncompletedTasks := h.todoList.ListUncompletedTasks()
-	b, err := json.MarshalIndent(uncompletedTasks, "", "  ")
+	b, err := json.MarshalIndent(pageDTO, "", "  ")
 	if err != nil {
 		panic(err)
 	}
@@ -174,7 +200,6 @@ func (h *HTTPHandlers) HandleGetAllUncompletedTasks(w http.ResponseWriter, r *ht
 		fmt.Println("failed to write http response", err)
 		return
 	}
-
 }
 
 /*
@@ -187,50 +212,41 @@ succeed:
 	-response body: JSON represented changed task
 
 failed:
-	-status code: 400, 500, 409
-	-response body: JSON with error + time
+	-status code: 400, 404, 500
+	-response body: JSON APIError
 
 */
 
 func (h *HTTPHandlers) HandleCompleteTask(w http.ResponseWriter, r *http.Request) {
 	var completeDTO CompleteTaskDTO
 	if err := json.NewDecoder(r.Body).Decode(&completeDTO); err != nil {
-		errDTO := ErrorDTO{
-			Message: err.Error(),
-			Time:    time.Now(),
-		}
-
-		http.Error(w, errDTO.ToString(), http.StatusBadRequest)
+		writeError(w, NewAPIError(CodeValidationFailed, err.Error()))
 		return
 	}
 
 	title := mux.Vars(r)["title"]
 
+	list, ok := h.callerList(w, r)
+	if !ok {
+		return
+	}
+
 	var (
 		changedTask todo.Task
 		err         error
 	)
 
 	if completeDTO.Complete {
-		changedTask, err = h.todoList.CompleteTask(title)
+		changedTask, err = list.CompleteTaskCtx(r.Context(), title)
 	} else {
-		changedTask, err = h.todoList.UncompleteTask(title)
+		changedTask, err = list.UncompleteTaskCtx(r.Context(), title)
 	}
 
 	if err != nil {
-		errDTO := ErrorDTO{
-			Message: err.Error(),
-			Time:    time.Now(),
-		}
-
-		if errors.Is(err, todo.ErrTaskNotFound) {
-			http.Error(w, errDTO.ToString(), http.StatusNotFound)
-		} else {
-			http.Error(w, errDTO.ToString(), http.StatusInternalServerError)
-		}
+		writeError(w, listAPIError(err))
 		return
-
 	}
+
 	b, err := json.MarshalIndent(changedTask, "", "  ")
 	if err != nil {
 		panic(err)
@@ -251,26 +267,69 @@ succeed:
 	-response body: -
 
 failed:
-	-status code: 400, 500, 409, 404
-	-response body: JSON with error + time
+	-status code: 404, 500
+	-response body: JSON APIError
 
 */
 
 func (h *HTTPHandlers) HandleDeleteTask(w http.ResponseWriter, r *http.Request) {
 	title := mux.Vars(r)["title"]
 
-	if err := h.todoList.DeleteTask(title); err != nil {
-		errDTO := ErrorDTO{
-			Message: err.Error(),
-			Time:    time.Now(),
-		}
+	list, ok := h.callerList(w, r)
+	if !ok {
+		return
+	}
+
+	if err := list.DeleteTaskCtx(r.Context(), title); err != nil {
+		writeError(w, listAPIError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+/*
+pattern: /admin/users/{userID}/tasks
+method: GET
+info: pattern, requires the caller's JWT to carry the "admin" role
+
+succeed:
+	-status code: 200 OK
+	-response body: JSON represented found tasks for the given user
+
+failed:
+	-status code: 403, 404, 500
+	-response body: JSON APIError
+*/
 
-		if errors.Is(err, todo.ErrTaskNotFound) {
-			http.Error(w, errDTO.ToString(), http.StatusNotFound)
-		} else {
-			http.Error(w, errDTO.ToString(), http.StatusInternalServerError)
-		}
+func (h *HTTPHandlers) HandleGetUserTasks(w http.ResponseWriter, r *http.Request) {
+	role, _ := roleFromContext(r.Context())
+	if role != users.RoleAdmin {
+		writeError(w, NewAPIError(CodeForbidden, "admin role required"))
+		return
+	}
 
+	targetUserID := mux.Vars(r)["userID"]
+
+	if !h.registry.Exists(targetUserID) {
+		writeError(w, NewAPIError(CodeUserNotFound, "user not found"))
+		return
+	}
+
+	list, err := h.lists.ListFor(targetUserID)
+	if err != nil {
+		writeError(w, NewAPIError(CodeInternal, "internal server error").WithCause(err))
+		return
+	}
+
+	tasks := list.ListTasks()
+	b, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(b); err != nil {
+		fmt.Println("failed to write http response", err)
 		return
 	}
 }
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"RestAPI/users"
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const bearerPrefix = "Bearer "
+
+// AuthMiddleware validates the Authorization: Bearer <token> header against
+// registry and injects the caller's user ID and role into the request
+// context for downstream handlers.
+func AuthMiddleware(registry *users.Registry) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if !strings.HasPrefix(header, bearerPrefix) {
+				writeError(w, NewAPIError(CodeUnauthenticated, "missing bearer token"))
+				return
+			}
+
+			claims, err := registry.ParseToken(strings.TrimPrefix(header, bearerPrefix))
+			if err != nil {
+				writeError(w, NewAPIError(CodeUnauthenticated, "invalid or expired token").WithCause(err))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userIDKey, claims.UserID)
+			ctx = context.WithValue(ctx, roleKey, claims.Role)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"RestAPI/todo"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// parseQueryOptions translates GET /tasks's query string into
+// todo.QueryOptions.
+func parseQueryOptions(q url.Values) (todo.QueryOptions, error) {
+	var opts todo.QueryOptions
+
+	if v := q.Get("completed"); v != "" {
+		completed, err := strconv.ParseBool(v)
+		if err != nil {
+			return todo.QueryOptions{}, fmt.Errorf("invalid completed: %q", v)
+		}
+		opts.Completed = &completed
+	}
+
+	if v := q.Get("created_after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return todo.QueryOptions{}, fmt.Errorf("invalid created_after: %q", v)
+		}
+		opts.CreatedAfter = &t
+	}
+
+	if v := q.Get("created_before"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return todo.QueryOptions{}, fmt.Errorf("invalid created_before: %q", v)
+		}
+		opts.CreatedBefore = &t
+	}
+
+	switch sortBy := todo.SortField(q.Get("sort")); sortBy {
+	case "":
+		opts.SortBy = todo.SortByCreatedAt
+	case todo.SortByCreatedAt, todo.SortByTitle, todo.SortByCompletedAt:
+		opts.SortBy = sortBy
+	default:
+		return todo.QueryOptions{}, fmt.Errorf("invalid sort: %q", sortBy)
+	}
+
+	switch order := todo.SortOrder(q.Get("order")); order {
+	case "":
+		opts.Order = todo.OrderAsc
+	case todo.OrderAsc, todo.OrderDesc:
+		opts.Order = order
+	default:
+		return todo.QueryOptions{}, fmt.Errorf("invalid order: %q", order)
+	}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			return todo.QueryOptions{}, fmt.Errorf("invalid limit: %q", v)
+		}
+		opts.Limit = limit
+	}
+
+	opts.Cursor = q.Get("cursor")
+
+	return opts, nil
+}
+
+// TasksPageDTO is the response body of GET /tasks.
+type TasksPageDTO struct {
+	Items      []todo.Task `json:"items"`
+	NextCursor string      `json:"next_cursor"`
+}
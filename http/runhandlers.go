@@ -0,0 +1,210 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const runLogsPollInterval = 500 * time.Millisecond
+
+/*
+pattern: /tasks/{title}/run
+method: POST
+info: pattern
+
+succeed:
+	-status code: 202 Accepted
+	-response body: JSON represented queued Run
+
+failed:
+	-status code: 400, 404, 500
+	-response body: JSON APIError
+*/
+
+func (h *HTTPHandlers) HandleRunTask(w http.ResponseWriter, r *http.Request) {
+	title := mux.Vars(r)["title"]
+
+	list, ok := h.callerList(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := userIDFromContext(r.Context())
+
+	task, err := list.GetTaskCtx(r.Context(), title)
+	if err != nil {
+		writeError(w, listAPIError(err))
+		return
+	}
+
+	if !task.Runnable() {
+		writeError(w, NewAPIError(CodeRunNotRunnable, "task has no command to run"))
+		return
+	}
+
+	runID, err := h.runner.Enqueue(userID, task)
+	if err != nil {
+		writeError(w, NewAPIError(CodeInternal, "internal server error").WithCause(err))
+		return
+	}
+
+	run, _ := h.runner.Get(userID, runID)
+
+	b, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	if _, err := w.Write(b); err != nil {
+		fmt.Println("failed to write http response", err)
+		return
+	}
+}
+
+/*
+pattern: /tasks/{title}/runs/{id}
+method: GET
+info: pattern
+
+succeed:
+	-status code: 200 OK
+	-response body: JSON represented Run
+
+failed:
+	-status code: 404
+	-response body: JSON APIError
+*/
+
+func (h *HTTPHandlers) HandleGetRun(w http.ResponseWriter, r *http.Request) {
+	runID := mux.Vars(r)["id"]
+
+	if _, ok := h.callerList(w, r); !ok {
+		return
+	}
+	userID, _ := userIDFromContext(r.Context())
+
+	run, ok := h.runner.Get(userID, runID)
+	if !ok {
+		writeError(w, NewAPIError(CodeRunNotFound, "run not found"))
+		return
+	}
+
+	b, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(b); err != nil {
+		fmt.Println("failed to write http response", err)
+		return
+	}
+}
+
+/*
+pattern: /tasks/{title}/runs/{id}/logs
+method: GET
+info: pattern; streams via Server-Sent Events until the run reaches a
+terminal state or the client disconnects
+
+succeed:
+	-status code: 200 OK
+	-response body: text/event-stream of log chunks, ending with a "done" event
+
+failed:
+	-status code: 404, 500
+	-response body: JSON APIError
+*/
+
+func (h *HTTPHandlers) HandleGetRunLogs(w http.ResponseWriter, r *http.Request) {
+	runID := mux.Vars(r)["id"]
+
+	if _, ok := h.callerList(w, r); !ok {
+		return
+	}
+	userID, _ := userIDFromContext(r.Context())
+
+	if _, ok := h.runner.Get(userID, runID); !ok {
+		writeError(w, NewAPIError(CodeRunNotFound, "run not found"))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, NewAPIError(CodeStreamingUnsupported, "streaming unsupported"))
+		return
+	}
+
+	// The server's WriteTimeout bounds a whole response; a log stream can
+	// legitimately outlive it, so this handler opts itself out and relies
+	// on the client disconnecting (or r.Context() being cancelled) to end
+	// the stream instead.
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		writeError(w, NewAPIError(CodeInternal, "internal server error").WithCause(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(runLogsPollInterval)
+	defer ticker.Stop()
+
+	var sent int
+	for {
+		logs, ok := h.runner.Logs(userID, runID)
+		if ok && len(logs) > sent {
+			fmt.Fprintf(w, "data: %s\n\n", strings.ReplaceAll(logs[sent:], "\n", "\\n"))
+			flusher.Flush()
+			sent = len(logs)
+		}
+
+		run, ok := h.runner.Get(userID, runID)
+		if !ok || run.State.Terminal() {
+			fmt.Fprintf(w, "event: done\ndata: %s\n\n", run.State)
+			flusher.Flush()
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/*
+pattern: /tasks/{title}/runs/{id}
+method: DELETE
+info: pattern; signals the run's process group to terminate
+
+succeed:
+	-status code: 204 No Content
+
+failed:
+	-status code: 404
+	-response body: JSON APIError
+*/
+
+func (h *HTTPHandlers) HandleCancelRun(w http.ResponseWriter, r *http.Request) {
+	runID := mux.Vars(r)["id"]
+
+	if _, ok := h.callerList(w, r); !ok {
+		return
+	}
+	userID, _ := userIDFromContext(r.Context())
+
+	if !h.runner.Cancel(userID, runID) {
+		writeError(w, NewAPIError(CodeRunNotFound, "run not found or already finished"))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,37 +1,123 @@
 package http
 
 import (
+	"RestAPI/users"
+	"context"
 	"errors"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/gorilla/mux"
 )
 
+const (
+	readTimeout = 5 * time.Second
+	// writeTimeout bounds a response's entire write. HandleGetRunLogs
+	// streams for as long as a run takes, so it lifts this deadline for
+	// itself via http.ResponseController.
+	writeTimeout    = 10 * time.Second
+	idleTimeout     = 60 * time.Second
+	shutdownTimeout = 15 * time.Second
+)
+
 type HTTPServer struct {
 	httpHandlers *HTTPHandlers
+	authHandlers *AuthHandlers
+	registry     *users.Registry
+	addr         string
+
+	shuttingDown atomic.Bool
 }
 
-func NewHTTPServer(httpHandler *HTTPHandlers) *HTTPServer {
+func NewHTTPServer(httpHandlers *HTTPHandlers, authHandlers *AuthHandlers, registry *users.Registry) *HTTPServer {
 	return &HTTPServer{
-		httpHandlers: httpHandler,
+		httpHandlers: httpHandlers,
+		authHandlers: authHandlers,
+		registry:     registry,
+		addr:         ":9091",
 	}
 }
 
+// StartServer runs the HTTP server until it errors or the process receives
+// SIGINT/SIGTERM, in which case it drains in-flight requests within
+// shutdownTimeout before returning.
 func (s *HTTPServer) StartServer() error {
 	router := mux.NewRouter()
+	router.Use(RecoverMiddleware)
+
+	router.Path("/signup").Methods("POST").HandlerFunc(s.authHandlers.HandleSignup)
+	router.Path("/login").Methods("POST").HandlerFunc(s.authHandlers.HandleLogin)
+	router.Path("/healthz").Methods("GET").HandlerFunc(s.handleHealthz)
+	router.Path("/readyz").Methods("GET").HandlerFunc(s.handleReadyz)
+
+	authed := router.NewRoute().Subrouter()
+	authed.Use(AuthMiddleware(s.registry))
 
-	router.Path("/tasks").Methods("POST").HandlerFunc(s.httpHandlers.HandleCreateTasks)
-	router.Path("/tasks/{title}").Methods("GET").HandlerFunc(s.httpHandlers.HandleGetTask)
-	router.Path("/tasks").Methods("GET").HandlerFunc(s.httpHandlers.HandleGetALLTasks)
-	router.Path("/tasks").Methods("GET").Queries("completed", "true").HandlerFunc(s.httpHandlers.HandleGetAllUncompletedTasks)
-	router.Path("/tasks/{title}").Methods("PATCH").HandlerFunc(s.httpHandlers.HandleCompleteTask)
-	router.Path("/tasks/{title}").Methods("DELETE").HandlerFunc(s.httpHandlers.HandleDeleteTask)
+	authed.Path("/tasks").Methods("POST").HandlerFunc(s.httpHandlers.HandleCreateTasks)
+	authed.Path("/tasks/{title}").Methods("GET").HandlerFunc(s.httpHandlers.HandleGetTask)
+	authed.Path("/tasks").Methods("GET").HandlerFunc(s.httpHandlers.HandleGetALLTasks)
+	authed.Path("/tasks/{title}").Methods("PATCH").HandlerFunc(s.httpHandlers.HandleCompleteTask)
+	authed.Path("/tasks/{title}").Methods("DELETE").HandlerFunc(s.httpHandlers.HandleDeleteTask)
+	authed.Path("/tasks/{title}/run").Methods("POST").HandlerFunc(s.httpHandlers.HandleRunTask)
+	authed.Path("/tasks/{title}/runs/{id}").Methods("GET").HandlerFunc(s.httpHandlers.HandleGetRun)
+	authed.Path("/tasks/{title}/runs/{id}/logs").Methods("GET").HandlerFunc(s.httpHandlers.HandleGetRunLogs)
+	authed.Path("/tasks/{title}/runs/{id}").Methods("DELETE").HandlerFunc(s.httpHandlers.HandleCancelRun)
+	authed.Path("/admin/users/{userID}/tasks").Methods("GET").HandlerFunc(s.httpHandlers.HandleGetUserTasks)
 
-	if err := http.ListenAndServe(":9091", router); err != nil {
-		if errors.Is(err, http.ErrServerClosed) {
-			return nil
+	srv := &http.Server{
+		Addr:         s.addr,
+		Handler:      router,
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+		IdleTimeout:  idleTimeout,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+			return
 		}
+		serveErr <- nil
+	}()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	select {
+	case err := <-serveErr:
 		return err
+	case <-sigCh:
+		s.shuttingDown.Store(true)
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			return err
+		}
+		return <-serveErr
+	}
+}
+
+// handleHealthz reports whether the process is alive. It stays healthy
+// while draining, since the process itself hasn't stopped yet.
+func (s *HTTPServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleReadyz reports whether the server is ready for new traffic. It
+// flips to unready as soon as shutdown starts, so a load balancer can stop
+// routing new requests while in-flight ones drain.
+func (s *HTTPServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if s.shuttingDown.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
 	}
-	return nil
+	w.WriteHeader(http.StatusOK)
 }
@@ -2,16 +2,64 @@ package main
 
 import (
 	"RestAPI/http"
+	"RestAPI/runner"
 	"RestAPI/todo"
+	"RestAPI/users"
+	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 )
 
 func main() {
-	todoList := todo.NewList()
-	httpHandlers := http.NewHTTPHandlers(todoList)
-	httpServer := http.NewHTTPServer(httpHandlers)
+	backend := flag.String("backend", "json", "storage backend to use: json or badger")
+	dataDir := flag.String("data-dir", "./data", "directory for persistent storage")
+	jwtSecret := flag.String("jwt-secret", "", "secret used to sign JWTs (required)")
+	bootstrapAdmin := flag.String("bootstrap-admin", "", "username granted the admin role on signup (optional)")
+	runConcurrency := flag.Int("run-concurrency", 4, "number of task commands that may run at once")
+	runBackoff := flag.Duration("run-backoff", time.Second, "base backoff between retry attempts of a failed task run")
+	flag.Parse()
+
+	if *jwtSecret == "" {
+		fmt.Println("Error: -jwt-secret is required")
+		os.Exit(1)
+	}
+
+	lists := todo.NewLists(func(userID string) (todo.Store, error) {
+		return newStore(*backend, filepath.Join(*dataDir, userID))
+	})
+
+	registry := users.NewRegistry([]byte(*jwtSecret), *bootstrapAdmin)
+
+	runStore, err := runner.NewJSONRunStore(filepath.Join(*dataDir, "runs"))
+	if err != nil {
+		fmt.Println("Error opening run store:", err)
+		os.Exit(1)
+	}
+
+	pool, err := runner.NewPool(*runConcurrency, *runBackoff, runStore)
+	if err != nil {
+		fmt.Println("Error starting runner pool:", err)
+		os.Exit(1)
+	}
+
+	httpHandlers := http.NewHTTPHandlers(lists, pool, registry)
+	authHandlers := http.NewAuthHandlers(registry)
+	httpServer := http.NewHTTPServer(httpHandlers, authHandlers, registry)
 
 	if err := httpServer.StartServer(); err != nil {
 		fmt.Println("Error starting http server:", err)
 	}
 }
+
+func newStore(backend, dataDir string) (todo.Store, error) {
+	switch backend {
+	case "json":
+		return todo.NewJSONStore(dataDir)
+	case "badger":
+		return todo.NewBadgerStore(dataDir)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", backend)
+	}
+}
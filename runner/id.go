@@ -0,0 +1,15 @@
+package runner
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+func newRunID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,261 @@
+package runner
+
+import (
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"RestAPI/todo"
+)
+
+const defaultRingBufferSize = 64 * 1024
+
+// queuedJob is a single request to run a task's command.
+type queuedJob struct {
+	task  todo.Task
+	runID string
+}
+
+// runEntry is a Pool's live bookkeeping for one Run.
+type runEntry struct {
+	run    todo.Run
+	output *RingBuffer
+	pid    int
+}
+
+// Pool executes queued tasks' commands in a fixed-size worker pool,
+// retrying failures with linear backoff up to the task's MaxAttempts, and
+// exposes their status and captured output for polling.
+type Pool struct {
+	queue   chan queuedJob
+	backoff time.Duration
+	store   RunStore
+
+	mtx  sync.Mutex
+	runs map[string]*runEntry
+}
+
+// NewPool starts concurrency workers pulling from an internal job queue.
+// store, if non-nil, is used to persist run history and to seed prior runs
+// back into memory on startup.
+func NewPool(concurrency int, backoff time.Duration, store RunStore) (*Pool, error) {
+	p := &Pool{
+		queue:   make(chan queuedJob, 128),
+		backoff: backoff,
+		store:   store,
+		runs:    make(map[string]*runEntry),
+	}
+
+	if store != nil {
+		runs, err := store.LoadRuns()
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range runs {
+			p.runs[run.ID] = &runEntry{run: run, output: NewRingBuffer(defaultRingBufferSize)}
+		}
+	}
+
+	for i := 0; i < concurrency; i++ {
+		go p.worker()
+	}
+
+	return p, nil
+}
+
+// Enqueue schedules task's command for execution on userID's behalf and
+// returns the ID of the resulting Run.
+func (p *Pool) Enqueue(userID string, task todo.Task) (string, error) {
+	runID, err := newRunID()
+	if err != nil {
+		return "", err
+	}
+
+	entry := &runEntry{
+		run: todo.Run{
+			ID:        runID,
+			UserID:    userID,
+			TaskTitle: task.Title,
+			State:     todo.RunPending,
+		},
+		output: NewRingBuffer(defaultRingBufferSize),
+	}
+
+	p.mtx.Lock()
+	p.runs[runID] = entry
+	p.mtx.Unlock()
+
+	p.saveRun(entry.run)
+
+	p.queue <- queuedJob{task: task, runID: runID}
+
+	return runID, nil
+}
+
+// Get returns a snapshot of the given run, scoped to userID. It reports
+// false if no such run exists or it belongs to a different user.
+func (p *Pool) Get(userID, runID string) (todo.Run, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	entry, ok := p.runs[runID]
+	if !ok || entry.run.UserID != userID {
+		return todo.Run{}, false
+	}
+
+	return entry.run, true
+}
+
+// Logs returns the captured combined stdout/stderr for the given run,
+// scoped to userID.
+func (p *Pool) Logs(userID, runID string) (string, bool) {
+	p.mtx.Lock()
+	entry, ok := p.runs[runID]
+	p.mtx.Unlock()
+
+	if !ok || entry.run.UserID != userID {
+		return "", false
+	}
+
+	return entry.output.String(), true
+}
+
+// Cancel signals the process group running runID to terminate, scoped to
+// userID. It reports whether a running (or not-yet-started) run belonging
+// to userID was found to cancel.
+func (p *Pool) Cancel(userID, runID string) bool {
+	p.mtx.Lock()
+	entry, ok := p.runs[runID]
+	if !ok || entry.run.UserID != userID || entry.run.State.Terminal() {
+		p.mtx.Unlock()
+		return false
+	}
+
+	pid := entry.pid
+	entry.run.State = todo.RunCancelled
+	run := entry.run
+	p.mtx.Unlock()
+
+	p.saveRun(run)
+
+	if pid != 0 {
+		// Negative pid targets the whole process group created via
+		// Setpgid, so children the command spawned die too.
+		_ = syscall.Kill(-pid, syscall.SIGTERM)
+	}
+
+	return true
+}
+
+func (p *Pool) worker() {
+	for job := range p.queue {
+		p.run(job)
+	}
+}
+
+func (p *Pool) run(job queuedJob) {
+	maxAttempts := job.task.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if p.isCancelled(job.runID) {
+			return
+		}
+
+		if p.attempt(job, attempt) {
+			return
+		}
+
+		if attempt < maxAttempts && !p.isCancelled(job.runID) {
+			time.Sleep(p.backoff * time.Duration(attempt))
+		}
+	}
+}
+
+// attempt runs job once and reports whether the run reached a final state
+// that should stop retries (success or cancellation).
+func (p *Pool) attempt(job queuedJob, attempt int) bool {
+	p.mtx.Lock()
+	entry, ok := p.runs[job.runID]
+	if !ok {
+		p.mtx.Unlock()
+		return true
+	}
+
+	started := time.Now()
+	entry.run.Attempt = attempt
+	entry.run.State = todo.RunRunning
+	entry.run.StartedAt = &started
+	entry.run.Error = ""
+	p.mtx.Unlock()
+
+	p.saveRun(entry.run)
+
+	cmd := exec.Command(job.task.Command, job.task.Args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Stdout = entry.output
+	cmd.Stderr = entry.output
+
+	startErr := cmd.Start()
+	if startErr == nil {
+		p.mtx.Lock()
+		entry.pid = cmd.Process.Pid
+		p.mtx.Unlock()
+	}
+
+	var runErr error
+	if startErr != nil {
+		runErr = startErr
+	} else {
+		runErr = cmd.Wait()
+	}
+
+	p.mtx.Lock()
+
+	finished := time.Now()
+	entry.run.FinishedAt = &finished
+	entry.run.Output = entry.output.String()
+	entry.pid = 0
+
+	done := true
+	switch {
+	case entry.run.State == todo.RunCancelled:
+		// Cancel() already set the terminal state; leave it as-is.
+	case runErr != nil:
+		// Only the last attempt's failure is terminal; with a retry still
+		// to come the run goes back to pending.
+		entry.run.State = todo.RunFailed
+		if attempt < job.task.MaxAttempts {
+			entry.run.State = todo.RunPending
+		}
+		entry.run.Error = runErr.Error()
+		done = false
+	default:
+		entry.run.State = todo.RunSucceeded
+	}
+
+	run := entry.run
+	p.mtx.Unlock()
+
+	p.saveRun(run)
+
+	return done
+}
+
+func (p *Pool) isCancelled(runID string) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	entry, ok := p.runs[runID]
+	return ok && entry.run.State == todo.RunCancelled
+}
+
+func (p *Pool) saveRun(run todo.Run) {
+	if p.store == nil {
+		return
+	}
+	_ = p.store.SaveRun(run)
+}
@@ -0,0 +1,36 @@
+package runner
+
+import "sync"
+
+// RingBuffer retains at most the last capacity bytes written to it,
+// discarding the oldest bytes once full. It implements io.Writer so it can
+// capture a running process's combined stdout/stderr without letting a
+// noisy command exhaust memory.
+type RingBuffer struct {
+	mtx sync.Mutex
+	buf []byte
+	cap int
+}
+
+func NewRingBuffer(capacity int) *RingBuffer {
+	return &RingBuffer{cap: capacity}
+}
+
+func (r *RingBuffer) Write(p []byte) (int, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.buf = append(r.buf, p...)
+	if len(r.buf) > r.cap {
+		r.buf = r.buf[len(r.buf)-r.cap:]
+	}
+
+	return len(p), nil
+}
+
+func (r *RingBuffer) String() string {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return string(r.buf)
+}
@@ -0,0 +1,90 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"RestAPI/todo"
+)
+
+const runsFileName = "runs.log"
+
+// RunStore persists Run history so it survives restarts.
+type RunStore interface {
+	SaveRun(run todo.Run) error
+	LoadRuns() ([]todo.Run, error)
+}
+
+// JSONRunStore is a RunStore backed by an append-only JSON lines file, one
+// record per state transition. The most recent record for a given run ID
+// wins on replay.
+type JSONRunStore struct {
+	mtx sync.Mutex
+	f   *os.File
+}
+
+func NewJSONRunStore(dir string) (*JSONRunStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, runsFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONRunStore{f: f}, nil
+}
+
+func (s *JSONRunStore) SaveRun(run todo.Run) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	b, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	return s.f.Sync()
+}
+
+// LoadRuns replays the run history file, returning the most recently
+// recorded state of each run.
+func (s *JSONRunStore) LoadRuns() ([]todo.Run, error) {
+	f, err := os.Open(s.f.Name())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	byID := make(map[string]todo.Run)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var run todo.Run
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			return nil, err
+		}
+		byID[run.ID] = run
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	runs := make([]todo.Run, 0, len(byID))
+	for _, run := range byID {
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,83 @@
+package todo
+
+import (
+	"encoding/json"
+
+	badger "github.com/dgraph-io/badger/v3"
+)
+
+// BadgerStore is a Store backed by BadgerDB. Badger's own value log and LSM
+// tree already provide durability, so Save/Delete write straight through
+// and Snapshot has nothing to do; it's kept only to satisfy Store.
+type BadgerStore struct {
+	db *badger.DB
+}
+
+// NewBadgerStore opens (creating if necessary) a Badger database in dir.
+func NewBadgerStore(dir string) (*BadgerStore, error) {
+	db, err := badger.Open(badger.DefaultOptions(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	return &BadgerStore{db: db}, nil
+}
+
+func (s *BadgerStore) Save(task Task) error {
+	b, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(task.Title), b)
+	})
+}
+
+func (s *BadgerStore) Delete(title string) error {
+	return s.db.Update(func(txn *badger.Txn) error {
+		err := txn.Delete([]byte(title))
+		if err == badger.ErrKeyNotFound {
+			return nil
+		}
+		return err
+	})
+}
+
+func (s *BadgerStore) Load() (map[string]Task, error) {
+	tasks := make(map[string]Task)
+
+	err := s.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+
+			var task Task
+			if err := item.Value(func(v []byte) error {
+				return json.Unmarshal(v, &task)
+			}); err != nil {
+				return err
+			}
+
+			tasks[string(item.Key())] = task
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Snapshot is a no-op: Badger persists every Save/Delete immediately.
+func (s *BadgerStore) Snapshot(tasks map[string]Task) error {
+	return nil
+}
+
+func (s *BadgerStore) Close() error {
+	return s.db.Close()
+}
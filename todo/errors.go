@@ -0,0 +1,8 @@
+package todo
+
+import "errors"
+
+var (
+	ErrTaskNotFound      = errors.New("task not found")
+	ErrTaskAlreadyExists = errors.New("task already exists")
+)
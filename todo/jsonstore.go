@@ -0,0 +1,182 @@
+package todo
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	snapshotFileName = "snapshot.json"
+	walFileName      = "wal.log"
+)
+
+type opKind string
+
+const (
+	opSave   opKind = "save"
+	opDelete opKind = "delete"
+)
+
+type walEvent struct {
+	Op    opKind `json:"op"`
+	Title string `json:"title"`
+	Task  Task   `json:"task,omitempty"`
+}
+
+// JSONStore is a Store backed by an append-only JSON write-ahead log with
+// periodic snapshot compaction. It's meant for small deployments that don't
+// want to pull in an external database.
+type JSONStore struct {
+	dir string
+
+	mtx sync.Mutex
+	wal *os.File
+}
+
+// NewJSONStore opens (creating if necessary) the WAL and snapshot files
+// inside dir.
+func NewJSONStore(dir string) (*JSONStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	wal, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONStore{
+		dir: dir,
+		wal: wal,
+	}, nil
+}
+
+func (s *JSONStore) Save(task Task) error {
+	return s.appendEvent(walEvent{Op: opSave, Title: task.Title, Task: task})
+}
+
+func (s *JSONStore) Delete(title string) error {
+	return s.appendEvent(walEvent{Op: opDelete, Title: title})
+}
+
+func (s *JSONStore) appendEvent(e walEvent) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.wal.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	return s.wal.Sync()
+}
+
+// Load rebuilds task state by reading the latest snapshot, then replaying
+// every WAL event recorded since that snapshot was taken.
+func (s *JSONStore) Load() (map[string]Task, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	tasks, err := s.loadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.replayWAL(tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (s *JSONStore) loadSnapshot() (map[string]Task, error) {
+	f, err := os.Open(filepath.Join(s.dir, snapshotFileName))
+	if os.IsNotExist(err) {
+		return make(map[string]Task), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tasks := make(map[string]Task)
+	if err := json.NewDecoder(f).Decode(&tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (s *JSONStore) replayWAL(tasks map[string]Task) error {
+	f, err := os.Open(filepath.Join(s.dir, walFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e walEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return err
+		}
+
+		switch e.Op {
+		case opSave:
+			tasks[e.Title] = e.Task
+		case opDelete:
+			delete(tasks, e.Title)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Snapshot writes tasks to snapshot.json and truncates the write-ahead log,
+// since every event in it is now reflected in the snapshot.
+func (s *JSONStore) Snapshot(tasks map[string]Task) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	tmpPath := filepath.Join(s.dir, snapshotFileName+".tmp")
+
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	if err := json.NewEncoder(f).Encode(tasks); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, filepath.Join(s.dir, snapshotFileName)); err != nil {
+		return err
+	}
+
+	if err := s.wal.Truncate(0); err != nil {
+		return err
+	}
+	_, err = s.wal.Seek(0, 0)
+	return err
+}
+
+func (s *JSONStore) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.wal.Close()
+}
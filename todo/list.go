@@ -2,15 +2,32 @@ package todo
 
 import "sync"
 
+// defaultSnapshotEvery is how many mutations accumulate before List asks
+// its Store to compact.
+const defaultSnapshotEvery = 100
+
 type List struct {
 	tasks map[string]Task
 	mtx   sync.RWMutex
+
+	store         Store
+	snapshotEvery int
+	mutations     int
 }
 
-func NewList() *List {
-	return &List{
-		tasks: make(map[string]Task),
+// NewList builds a List backed by store. On startup it replays the store's
+// write-ahead log on top of the latest snapshot to restore prior state.
+func NewList(store Store) (*List, error) {
+	tasks, err := store.Load()
+	if err != nil {
+		return nil, err
 	}
+
+	return &List{
+		tasks:         tasks,
+		store:         store,
+		snapshotEvery: defaultSnapshotEvery,
+	}, nil
 }
 
 func (l *List) AddTask(task Task) error {
@@ -18,11 +35,15 @@ func (l *List) AddTask(task Task) error {
 	defer l.mtx.Unlock()
 
 	if _, ok := l.tasks[task.Title]; ok {
-
 		return ErrTaskAlreadyExists
 	}
 
+	if err := l.store.Save(task); err != nil {
+		return err
+	}
+
 	l.tasks[task.Title] = task
+	l.afterMutation()
 
 	return nil
 }
@@ -65,47 +86,76 @@ func (l *List) ListUncompletedTasks() map[string]Task {
 	return uncompletedTasks
 }
 
-func (l *List) CompleteTask(title string) error {
+func (l *List) CompleteTask(title string) (Task, error) {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
 
 	task, ok := l.tasks[title]
 	if !ok {
-		return ErrTaskNotFound
+		return Task{}, ErrTaskNotFound
 	}
 
 	task.Complete()
 
+	if err := l.store.Save(task); err != nil {
+		return Task{}, err
+	}
+
 	l.tasks[title] = task
+	l.afterMutation()
 
-	return nil
+	return task, nil
 }
 
-func (l *List) UncompleteTask(title string) error {
+func (l *List) UncompleteTask(title string) (Task, error) {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
 
 	task, ok := l.tasks[title]
 	if !ok {
-		return ErrTaskNotFound
+		return Task{}, ErrTaskNotFound
 	}
 
 	task.Uncomplete()
 
+	if err := l.store.Save(task); err != nil {
+		return Task{}, err
+	}
+
 	l.tasks[title] = task
+	l.afterMutation()
 
-	return nil
+	return task, nil
 }
 
 func (l *List) DeleteTask(title string) error {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
-	
-	_, ok := l.tasks[title]
-	if !ok {
+
+	if _, ok := l.tasks[title]; !ok {
 		return ErrTaskNotFound
 	}
+
+	if err := l.store.Delete(title); err != nil {
+		return err
+	}
+
 	delete(l.tasks, title)
+	l.afterMutation()
 
 	return nil
 }
+
+// afterMutation is called with mtx held after every mutating method has
+// durably recorded its event. It asks the store to compact once enough
+// mutations have piled up since the last snapshot.
+func (l *List) afterMutation() {
+	l.mutations++
+	if l.snapshotEvery <= 0 || l.mutations < l.snapshotEvery {
+		return
+	}
+
+	if err := l.store.Snapshot(l.tasks); err == nil {
+		l.mutations = 0
+	}
+}
@@ -0,0 +1,169 @@
+package todo
+
+import "context"
+
+// withLockCtx runs fn while holding mtx for writing, unless ctx is done
+// first. Once the lock is acquired, ctx is checked again before fn runs,
+// so a cancellation that lands while still waiting on the lock reliably
+// skips the mutation. This narrows, but does not close, the gap: ctx can
+// still be cancelled after that check while fn (e.g. store.Save) is
+// running, in which case the caller sees ctx.Err() even though the write
+// went on to commit. Callers that need fn's completion and its
+// cancellation outcome to agree in every case should not rely on this
+// helper.
+func (l *List) withLockCtx(ctx context.Context, fn func() error) error {
+	done := make(chan error, 1)
+
+	go func() {
+		l.mtx.Lock()
+		defer l.mtx.Unlock()
+
+		if err := ctx.Err(); err != nil {
+			done <- err
+			return
+		}
+
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// withRLockCtx is the read-lock counterpart of withLockCtx.
+func (l *List) withRLockCtx(ctx context.Context, fn func()) error {
+	done := make(chan struct{})
+
+	go func() {
+		l.mtx.RLock()
+		defer l.mtx.RUnlock()
+		fn()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// AddTaskCtx is the context-aware variant of AddTask: it abandons the wait
+// for the list's lock as soon as ctx is done.
+func (l *List) AddTaskCtx(ctx context.Context, task Task) error {
+	return l.withLockCtx(ctx, func() error {
+		if _, ok := l.tasks[task.Title]; ok {
+			return ErrTaskAlreadyExists
+		}
+
+		if err := l.store.Save(task); err != nil {
+			return err
+		}
+
+		l.tasks[task.Title] = task
+		l.afterMutation()
+
+		return nil
+	})
+}
+
+// GetTaskCtx is the context-aware variant of GetTask.
+func (l *List) GetTaskCtx(ctx context.Context, title string) (Task, error) {
+	var (
+		task  Task
+		found bool
+	)
+
+	if err := l.withRLockCtx(ctx, func() {
+		task, found = l.tasks[title]
+	}); err != nil {
+		return Task{}, err
+	}
+
+	if !found {
+		return Task{}, ErrTaskNotFound
+	}
+
+	return task, nil
+}
+
+// CompleteTaskCtx is the context-aware variant of CompleteTask.
+func (l *List) CompleteTaskCtx(ctx context.Context, title string) (Task, error) {
+	var changed Task
+
+	err := l.withLockCtx(ctx, func() error {
+		task, ok := l.tasks[title]
+		if !ok {
+			return ErrTaskNotFound
+		}
+
+		task.Complete()
+
+		if err := l.store.Save(task); err != nil {
+			return err
+		}
+
+		l.tasks[title] = task
+		l.afterMutation()
+		changed = task
+
+		return nil
+	})
+	if err != nil {
+		return Task{}, err
+	}
+
+	return changed, nil
+}
+
+// UncompleteTaskCtx is the context-aware variant of UncompleteTask.
+func (l *List) UncompleteTaskCtx(ctx context.Context, title string) (Task, error) {
+	var changed Task
+
+	err := l.withLockCtx(ctx, func() error {
+		task, ok := l.tasks[title]
+		if !ok {
+			return ErrTaskNotFound
+		}
+
+		task.Uncomplete()
+
+		if err := l.store.Save(task); err != nil {
+			return err
+		}
+
+		l.tasks[title] = task
+		l.afterMutation()
+		changed = task
+
+		return nil
+	})
+	if err != nil {
+		return Task{}, err
+	}
+
+	return changed, nil
+}
+
+// DeleteTaskCtx is the context-aware variant of DeleteTask.
+func (l *List) DeleteTaskCtx(ctx context.Context, title string) error {
+	return l.withLockCtx(ctx, func() error {
+		if _, ok := l.tasks[title]; !ok {
+			return ErrTaskNotFound
+		}
+
+		if err := l.store.Delete(title); err != nil {
+			return err
+		}
+
+		delete(l.tasks, title)
+		l.afterMutation()
+
+		return nil
+	})
+}
@@ -0,0 +1,64 @@
+package todo
+
+import (
+	"fmt"
+	"sync"
+)
+
+// StoreFactory builds the Store backing a given user's task list, e.g.
+// rooted at a per-user data directory.
+type StoreFactory func(userID string) (Store, error)
+
+// Lists vends a per-user *List, lazily creating one (and its backing
+// Store) the first time a user is seen.
+type Lists struct {
+	mtx          sync.Mutex
+	byUser       map[string]*List
+	storeFactory StoreFactory
+}
+
+func NewLists(storeFactory StoreFactory) *Lists {
+	return &Lists{
+		byUser:       make(map[string]*List),
+		storeFactory: storeFactory,
+	}
+}
+
+// ListFor returns the List belonging to userID, creating it if this is the
+// first time userID has been seen.
+func (ls *Lists) ListFor(userID string) (*List, error) {
+	ls.mtx.Lock()
+	defer ls.mtx.Unlock()
+
+	if list, ok := ls.byUser[userID]; ok {
+		return list, nil
+	}
+
+	store, err := ls.storeFactory(userID)
+	if err != nil {
+		return nil, fmt.Errorf("build store for user %q: %w", userID, err)
+	}
+
+	list, err := NewList(store)
+	if err != nil {
+		return nil, fmt.Errorf("load list for user %q: %w", userID, err)
+	}
+
+	ls.byUser[userID] = list
+
+	return list, nil
+}
+
+// UserIDs returns the IDs of every user who has a list, for admin
+// inspection endpoints.
+func (ls *Lists) UserIDs() []string {
+	ls.mtx.Lock()
+	defer ls.mtx.Unlock()
+
+	ids := make([]string, 0, len(ls.byUser))
+	for id := range ls.byUser {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
@@ -0,0 +1,189 @@
+package todo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SortField is a Task field Query can order results by.
+type SortField string
+
+const (
+	SortByCreatedAt   SortField = "created_at"
+	SortByTitle       SortField = "title"
+	SortByCompletedAt SortField = "completed_at"
+)
+
+type SortOrder string
+
+const (
+	OrderAsc  SortOrder = "asc"
+	OrderDesc SortOrder = "desc"
+)
+
+// QueryOptions filters, sorts, and paginates a call to List.Query.
+type QueryOptions struct {
+	Completed     *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	SortBy SortField
+	Order  SortOrder
+
+	Limit  int
+	Cursor string
+}
+
+// Page is one page of a Query result.
+type Page struct {
+	Items      []Task
+	NextCursor string
+}
+
+// cursor is the opaque state carried between pages: the sort key of the
+// last item returned, plus its title as a tiebreaker so equal sort keys
+// still resume deterministically. Because a page resumes by comparing
+// keys rather than looking the item back up, a cursor minted from an item
+// that's since been deleted still resumes correctly at the next
+// surviving key.
+type cursor struct {
+	SortKey string `json:"sort_key"`
+	Title   string `json:"title"`
+}
+
+func encodeCursor(c cursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(s string) (cursor, error) {
+	b, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return cursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return c, nil
+}
+
+// sortKeyTimeLayout is a fixed-width timestamp layout, so that lexical order
+// of formatted UTC times matches chronological order.
+const sortKeyTimeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// sortKey returns task's value for field, formatted so a plain string
+// comparison sorts correctly.
+func sortKey(task Task, field SortField) string {
+	switch field {
+	case SortByTitle:
+		return task.Title
+	case SortByCompletedAt:
+		if task.CompletedAt == nil {
+			return ""
+		}
+		return task.CompletedAt.UTC().Format(sortKeyTimeLayout)
+	default:
+		return task.CreatedAt.UTC().Format(sortKeyTimeLayout)
+	}
+}
+
+// Query returns a stably-ordered, filtered, paginated view of the list's
+// tasks.
+func (l *List) Query(opts QueryOptions) (Page, error) {
+	l.mtx.RLock()
+	tasks := make([]Task, 0, len(l.tasks))
+	for _, task := range l.tasks {
+		tasks = append(tasks, task)
+	}
+	l.mtx.RUnlock()
+
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = SortByCreatedAt
+	}
+
+	tasks = filterTasks(tasks, opts)
+
+	sort.Slice(tasks, func(i, j int) bool {
+		ki, kj := sortKey(tasks[i], sortBy), sortKey(tasks[j], sortBy)
+		if ki == kj {
+			return tasks[i].Title < tasks[j].Title
+		}
+		if opts.Order == OrderDesc {
+			return ki > kj
+		}
+		return ki < kj
+	})
+
+	if opts.Cursor != "" {
+		c, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return Page{}, err
+		}
+
+		start := len(tasks)
+		for i, task := range tasks {
+			key := sortKey(task, sortBy)
+
+			after := key > c.SortKey || (key == c.SortKey && task.Title > c.Title)
+			if opts.Order == OrderDesc {
+				after = key < c.SortKey || (key == c.SortKey && task.Title > c.Title)
+			}
+
+			if after {
+				start = i
+				break
+			}
+		}
+
+		tasks = tasks[start:]
+	}
+
+	limit := opts.Limit
+	if limit <= 0 || limit > len(tasks) {
+		limit = len(tasks)
+	}
+
+	page := Page{Items: tasks[:limit]}
+
+	if limit < len(tasks) {
+		last := page.Items[len(page.Items)-1]
+
+		nextCursor, err := encodeCursor(cursor{SortKey: sortKey(last, sortBy), Title: last.Title})
+		if err != nil {
+			return Page{}, err
+		}
+		page.NextCursor = nextCursor
+	}
+
+	return page, nil
+}
+
+func filterTasks(tasks []Task, opts QueryOptions) []Task {
+	filtered := tasks[:0:0]
+
+	for _, task := range tasks {
+		if opts.Completed != nil && task.Completed != *opts.Completed {
+			continue
+		}
+		if opts.CreatedAfter != nil && !task.CreatedAt.After(*opts.CreatedAfter) {
+			continue
+		}
+		if opts.CreatedBefore != nil && !task.CreatedAt.Before(*opts.CreatedBefore) {
+			continue
+		}
+		filtered = append(filtered, task)
+	}
+
+	return filtered
+}
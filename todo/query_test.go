@@ -0,0 +1,196 @@
+package todo
+
+import (
+	"testing"
+	"time"
+)
+
+// memoryStore is a minimal in-memory Store for exercising List without
+// touching disk.
+type memoryStore struct {
+	tasks map[string]Task
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{tasks: make(map[string]Task)}
+}
+
+func (s *memoryStore) Save(task Task) error {
+	s.tasks[task.Title] = task
+	return nil
+}
+
+func (s *memoryStore) Delete(title string) error {
+	delete(s.tasks, title)
+	return nil
+}
+
+func (s *memoryStore) Load() (map[string]Task, error) {
+	tasks := make(map[string]Task, len(s.tasks))
+	for k, v := range s.tasks {
+		tasks[k] = v
+	}
+	return tasks, nil
+}
+
+func (s *memoryStore) Snapshot(map[string]Task) error {
+	return nil
+}
+
+func newTestList(t *testing.T, tasks ...Task) *List {
+	t.Helper()
+
+	list, err := NewList(newMemoryStore())
+	if err != nil {
+		t.Fatalf("NewList: %v", err)
+	}
+
+	for _, task := range tasks {
+		if err := list.AddTask(task); err != nil {
+			t.Fatalf("AddTask(%q): %v", task.Title, err)
+		}
+	}
+
+	return list
+}
+
+func TestQueryCursorRoundTrip(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	titles := []string{"a", "b", "c", "d", "e"}
+
+	var tasks []Task
+	for i, title := range titles {
+		task := NewTask(title, "desc")
+		task.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		tasks = append(tasks, task)
+	}
+
+	list := newTestList(t, tasks...)
+
+	var got []string
+	cur := ""
+	for {
+		page, err := list.Query(QueryOptions{SortBy: SortByCreatedAt, Order: OrderAsc, Limit: 2, Cursor: cur})
+		if err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+
+		for _, item := range page.Items {
+			got = append(got, item.Title)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		cur = page.NextCursor
+	}
+
+	if len(got) != len(titles) {
+		t.Fatalf("got %v, want %v", got, titles)
+	}
+	for i, title := range titles {
+		if got[i] != title {
+			t.Errorf("position %d: got %q, want %q", i, got[i], title)
+		}
+	}
+}
+
+func TestQueryCursorRoundTripDescendingTiedKey(t *testing.T) {
+	titles := []string{"a", "b", "c", "d", "e"}
+
+	var tasks []Task
+	for _, title := range titles {
+		tasks = append(tasks, NewTask(title, "desc"))
+	}
+
+	list := newTestList(t, tasks...)
+
+	var got []string
+	cur := ""
+	for {
+		page, err := list.Query(QueryOptions{SortBy: SortByCompletedAt, Order: OrderDesc, Limit: 2, Cursor: cur})
+		if err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+
+		for _, item := range page.Items {
+			got = append(got, item.Title)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		cur = page.NextCursor
+	}
+
+	if len(got) != len(titles) {
+		t.Fatalf("got %v, want %v", got, titles)
+	}
+	for i, title := range titles {
+		if got[i] != title {
+			t.Errorf("position %d: got %q, want %q", i, got[i], title)
+		}
+	}
+}
+
+func TestQueryEmptyPage(t *testing.T) {
+	list := newTestList(t, NewTask("a", "desc"))
+
+	completed := true
+	page, err := list.Query(QueryOptions{Completed: &completed})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+
+	if len(page.Items) != 0 {
+		t.Fatalf("got %d items, want 0", len(page.Items))
+	}
+	if page.NextCursor != "" {
+		t.Fatalf("got cursor %q, want empty", page.NextCursor)
+	}
+}
+
+func TestQueryCursorAfterDeletedItemResumesAtNextKey(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	titles := []string{"a", "b", "c"}
+
+	var tasks []Task
+	for i, title := range titles {
+		task := NewTask(title, "desc")
+		task.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		tasks = append(tasks, task)
+	}
+
+	list := newTestList(t, tasks...)
+
+	first, err := list.Query(QueryOptions{SortBy: SortByCreatedAt, Order: OrderAsc, Limit: 1})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if first.NextCursor == "" {
+		t.Fatalf("expected a cursor after the first page")
+	}
+
+	if err := list.DeleteTask("b"); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+
+	second, err := list.Query(QueryOptions{SortBy: SortByCreatedAt, Order: OrderAsc, Limit: 10, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+
+	if len(second.Items) != 1 || second.Items[0].Title != "c" {
+		t.Fatalf("got %v, want [c]", second.Items)
+	}
+}
+
+func TestQueryInvalidCursor(t *testing.T) {
+	list := newTestList(t, NewTask("a", "desc"))
+
+	if _, err := list.Query(QueryOptions{Cursor: "not-valid-base64!!"}); err == nil {
+		t.Fatal("expected an error for a malformed cursor")
+	}
+}
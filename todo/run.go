@@ -0,0 +1,40 @@
+package todo
+
+import "time"
+
+// RunState is the lifecycle state of a Run.
+type RunState string
+
+const (
+	RunPending   RunState = "pending"
+	RunRunning   RunState = "running"
+	RunSucceeded RunState = "succeeded"
+	RunFailed    RunState = "failed"
+	RunCancelled RunState = "cancelled"
+)
+
+// Terminal reports whether no further transitions are expected for a run
+// in this state.
+func (s RunState) Terminal() bool {
+	switch s {
+	case RunSucceeded, RunFailed, RunCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run is one execution of a task's Command, across all of its retry
+// attempts; Attempt is the number of the current or last attempt.
+type Run struct {
+	ID        string
+	UserID    string
+	TaskTitle string
+	State     RunState
+	Attempt   int
+	Output    string
+	Error     string
+
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
@@ -0,0 +1,22 @@
+package todo
+
+// Store is a pluggable persistence backend for a List. Implementations are
+// responsible for durably recording every mutation so that a List can be
+// rebuilt after a restart.
+type Store interface {
+	// Save durably records task, overwriting any previous version stored
+	// under the same title.
+	Save(task Task) error
+
+	// Delete durably records removal of the task with the given title.
+	Delete(title string) error
+
+	// Load rebuilds the full set of tasks from durable storage, replaying
+	// the write-ahead log on top of the latest snapshot.
+	Load() (map[string]Task, error)
+
+	// Snapshot compacts tasks into a new snapshot, allowing the
+	// write-ahead log to be truncated. Backends that don't need a
+	// separate WAL/snapshot split may implement it as a no-op.
+	Snapshot(tasks map[string]Task) error
+}
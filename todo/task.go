@@ -7,6 +7,13 @@ type Task struct {
 	Description string
 	Completed   bool
 
+	// Command and Args, if set, make this task runnable: a worker pool
+	// can execute Command with Args as a queued job. MaxAttempts bounds
+	// retry-with-backoff on failure; 0 means a single attempt.
+	Command     string
+	Args        []string
+	MaxAttempts int
+
 	CreatedAt   time.Time
 	CompletedAt *time.Time
 }
@@ -21,6 +28,11 @@ func NewTask(title string, description string) Task {
 	}
 }
 
+// Runnable reports whether the task carries a command a runner can execute.
+func (t Task) Runnable() bool {
+	return t.Command != ""
+}
+
 func (t *Task) Complete() {
 	completeTime := time.Now()
 
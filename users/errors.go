@@ -0,0 +1,8 @@
+package users
+
+import "errors"
+
+var (
+	ErrUserAlreadyExists  = errors.New("user already exists")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+)
@@ -0,0 +1,12 @@
+package users
+
+import "github.com/dgrijalva/jwt-go"
+
+// Claims is the JWT payload issued on login: it carries enough to identify
+// the caller and their permission level without a round-trip to the
+// registry on every request.
+type Claims struct {
+	UserID string `json:"user_id"`
+	Role   Role   `json:"role"`
+	jwt.StandardClaims
+}
@@ -0,0 +1,122 @@
+package users
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const tokenTTL = 24 * time.Hour
+
+// Registry is an in-memory set of users that can sign up, authenticate,
+// and issue JWTs signed with jwtSecret.
+type Registry struct {
+	mtx            sync.RWMutex
+	users          map[string]User // keyed by username
+	jwtSecret      []byte
+	bootstrapAdmin string
+}
+
+// NewRegistry builds a Registry. If bootstrapAdmin is non-empty, the first
+// user to sign up under that username is granted RoleAdmin instead of the
+// default RoleUser, giving an operator a way to reach adminhood without a
+// promote endpoint of its own.
+func NewRegistry(jwtSecret []byte, bootstrapAdmin string) *Registry {
+	return &Registry{
+		users:          make(map[string]User),
+		jwtSecret:      jwtSecret,
+		bootstrapAdmin: bootstrapAdmin,
+	}
+}
+
+// Signup creates a new user, granting RoleAdmin to the configured
+// bootstrap admin username and RoleUser to everyone else.
+func (r *Registry) Signup(username, password string) (User, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.users[username]; ok {
+		return User{}, ErrUserAlreadyExists
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return User{}, err
+	}
+
+	role := RoleUser
+	if r.bootstrapAdmin != "" && username == r.bootstrapAdmin {
+		role = RoleAdmin
+	}
+
+	user := User{
+		ID:           username,
+		Username:     username,
+		PasswordHash: hash,
+		Role:         role,
+	}
+	r.users[username] = user
+
+	return user, nil
+}
+
+// Exists reports whether userID belongs to a signed-up user.
+func (r *Registry) Exists(userID string) bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	_, ok := r.users[userID]
+	return ok
+}
+
+// Login verifies username/password and returns a signed JWT on success.
+func (r *Registry) Login(username, password string) (string, error) {
+	r.mtx.RLock()
+	user, ok := r.users[username]
+	r.mtx.RUnlock()
+
+	if !ok {
+		return "", ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(password)); err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	return r.issueToken(user)
+}
+
+func (r *Registry) issueToken(user User) (string, error) {
+	claims := Claims{
+		UserID: user.ID,
+		Role:   user.Role,
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: time.Now().Add(tokenTTL).Unix(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(r.jwtSecret)
+}
+
+// ParseToken validates tokenString's signature and expiry and returns the
+// claims it carries.
+func (r *Registry) ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return r.jwtSecret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
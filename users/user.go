@@ -0,0 +1,16 @@
+package users
+
+// Role is a coarse permission level carried in a user's JWT claims.
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+type User struct {
+	ID           string
+	Username     string
+	PasswordHash []byte
+	Role         Role
+}